@@ -0,0 +1,213 @@
+package structdocs
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// JSONSchemaRenderer renders a struct, and every struct it transitively
+// references, as a JSON Schema Draft 2020-12 document. The requested
+// struct is the document root; every struct it reaches becomes a
+// "$defs" entry referenced via "$ref".
+type JSONSchemaRenderer struct{}
+
+// Render writes a JSON Schema document describing s to w.
+func (JSONSchemaRenderer) Render(w io.Writer, p *Parser, s StructInfo) error {
+	defs := map[string]any{}
+	registerSchemaDef(p, s, defs, map[string]bool{})
+
+	doc := map[string]any{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$ref":    "#/$defs/" + s.Name,
+		"$defs":   defs,
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
+
+// registerSchemaDef adds s's object schema to defs under its bare type name,
+// recursing into any struct fields it references. visiting guards against
+// infinite recursion on cyclic struct graphs.
+func registerSchemaDef(p *Parser, s StructInfo, defs map[string]any, visiting map[string]bool) {
+	if _, exists := defs[s.Name]; exists {
+		return
+	}
+	if visiting[s.key()] {
+		return
+	}
+	visiting[s.key()] = true
+	schema := buildObjectSchema(p, s, defs, visiting)
+	defs[s.Name] = schema
+	delete(visiting, s.key())
+}
+
+// buildObjectSchema renders s as a JSON Schema object, promoting embedded
+// struct fields (and their "required" entries) directly into the parent.
+func buildObjectSchema(p *Parser, s StructInfo, defs map[string]any, visiting map[string]bool) map[string]any {
+	properties := map[string]any{}
+	var required []string
+	for _, f := range s.Fields {
+		if f.Embedded {
+			if nested, ok := findStruct(p, f.elemKey); ok {
+				registerSchemaDef(p, nested, defs, visiting)
+				if nestedSchema, ok := defs[nested.Name].(map[string]any); ok {
+					if props, ok := nestedSchema["properties"].(map[string]any); ok {
+						for name, schema := range props {
+							properties[name] = schema
+						}
+					}
+					if nestedReq, ok := nestedSchema["required"].([]string); ok {
+						required = append(required, nestedReq...)
+					}
+				}
+			}
+			continue
+		}
+
+		name := f.Tags["json"]
+		if name == "" {
+			name = f.Tag
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+
+		fieldSchema := schemaForType(p, f.Type, f.elemKey, defs, visiting)
+		if f.Description != "" {
+			fieldSchema["description"] = f.Description
+		}
+		applyMarkers(fieldSchema, f.Markers)
+		properties[name] = fieldSchema
+
+		if _, ok := f.Markers["required"]; ok {
+			required = append(required, name)
+		}
+	}
+
+	schema := map[string]any{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// applyMarkers translates the recognized "+name=value" doc comment markers
+// (default, example, enum, minimum, maximum) into their JSON Schema
+// keywords on schema, converting values to match schema's "type" where
+// possible. "required" is handled by the caller, since it's a property of
+// the parent object schema rather than of the field's own schema.
+func applyMarkers(schema map[string]any, markers map[string][]string) {
+	if v, ok := markers["default"]; ok && len(v) > 0 {
+		schema["default"] = convertMarkerValue(v[0], schema)
+	}
+	if v, ok := markers["example"]; ok && len(v) > 0 {
+		schema["examples"] = []any{convertMarkerValue(v[0], schema)}
+	}
+	if v, ok := markers["enum"]; ok && len(v) > 0 {
+		enum := make([]any, len(v))
+		for i, e := range v {
+			enum[i] = convertMarkerValue(e, schema)
+		}
+		schema["enum"] = enum
+	}
+	if v, ok := markers["minimum"]; ok && len(v) > 0 {
+		if n, err := strconv.ParseFloat(v[0], 64); err == nil {
+			schema["minimum"] = n
+		}
+	}
+	if v, ok := markers["maximum"]; ok && len(v) > 0 {
+		if n, err := strconv.ParseFloat(v[0], 64); err == nil {
+			schema["maximum"] = n
+		}
+	}
+}
+
+// convertMarkerValue converts a marker's raw string value to match schema's
+// declared "type", falling back to the raw string when it doesn't parse.
+func convertMarkerValue(raw string, schema map[string]any) any {
+	switch schema["type"] {
+	case "integer":
+		if n, err := strconv.ParseInt(raw, 10, 64); err == nil {
+			return n
+		}
+	case "number":
+		if n, err := strconv.ParseFloat(raw, 64); err == nil {
+			return n
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(raw); err == nil {
+			return b
+		}
+	}
+	return raw
+}
+
+// schemaForType maps a rendered field type (as produced by resolveType) to
+// its JSON Schema representation, recursing through pointer and slice
+// wrappers and dereferencing struct references via elemKey.
+func schemaForType(p *Parser, typ, elemKey string, defs map[string]any, visiting map[string]bool) map[string]any {
+	switch {
+	case strings.HasPrefix(typ, "*"):
+		schema := schemaForType(p, strings.TrimPrefix(typ, "*"), elemKey, defs, visiting)
+		return nullableSchema(schema)
+	case strings.HasPrefix(typ, "[]"):
+		return map[string]any{
+			"type":  "array",
+			"items": schemaForType(p, strings.TrimPrefix(typ, "[]"), elemKey, defs, visiting),
+		}
+	case strings.HasPrefix(typ, "map["):
+		return map[string]any{
+			"type":                 "object",
+			"additionalProperties": schemaForType(p, mapValueType(typ), elemKey, defs, visiting),
+		}
+	}
+
+	if elemKey != "" {
+		if nested, ok := findStruct(p, elemKey); ok {
+			registerSchemaDef(p, nested, defs, visiting)
+			return map[string]any{"$ref": "#/$defs/" + nested.Name}
+		}
+	}
+
+	if jsonType, ok := basicJSONSchemaType(typ); ok {
+		return map[string]any{"type": jsonType}
+	}
+
+	// Unrecognized type (e.g. "unknown"); accept any value rather than guess.
+	return map[string]any{}
+}
+
+// nullableSchema widens schema to also accept null, matching the normal
+// Go zero value for a pointer field. A bare "type" schema uses the 2020-12
+// array form ("type": ["string", "null"]); anything else ($ref, array,
+// object) falls back to "anyOf", since a $ref can't be combined with other
+// keywords in the same schema object.
+func nullableSchema(schema map[string]any) map[string]any {
+	if t, ok := schema["type"].(string); ok && len(schema) == 1 {
+		return map[string]any{"type": []any{t, "null"}}
+	}
+	return map[string]any{"anyOf": []any{schema, map[string]any{"type": "null"}}}
+}
+
+func basicJSONSchemaType(goType string) (string, bool) {
+	switch goType {
+	case "string":
+		return "string", true
+	case "bool":
+		return "boolean", true
+	case "int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64", "uintptr", "byte", "rune":
+		return "integer", true
+	case "float32", "float64":
+		return "number", true
+	default:
+		return "", false
+	}
+}
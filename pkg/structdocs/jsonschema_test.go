@@ -0,0 +1,277 @@
+package structdocs
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+func TestJSONSchemaRenderer_NestedAndSlices(t *testing.T) {
+	tmpdir := t.TempDir()
+	initTempModule(t, tmpdir)
+	writeTempGoFile(t, tmpdir, "tool.go", exampleStructs)
+
+	p := NewParser()
+	if err := p.Load(tmpdir, "", true); err != nil {
+		t.Fatal(err)
+	}
+
+	tool := p.structs[testdataPkg+".Tool"]
+	var buf bytes.Buffer
+	if err := (JSONSchemaRenderer{}).Render(&buf, p, tool); err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+
+	if doc["$ref"] != "#/$defs/Tool" {
+		t.Errorf("expected root $ref to Tool, got %v", doc["$ref"])
+	}
+
+	defs, ok := doc["$defs"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected $defs object, got %T", doc["$defs"])
+	}
+	if _, ok := defs["ToolInstall"]; !ok {
+		t.Errorf("expected nested ToolInstall in $defs, got %v", defs)
+	}
+
+	toolDef := defs["Tool"].(map[string]any)
+	props := toolDef["properties"].(map[string]any)
+
+	name := props["name"].(map[string]any)
+	if name["type"] != "string" {
+		t.Errorf("expected name field to be string, got %v", name["type"])
+	}
+
+	installs := props["installs"].(map[string]any)
+	if installs["type"] != "array" {
+		t.Errorf("expected installs field to be array, got %v", installs["type"])
+	}
+	items := installs["items"].(map[string]any)
+	anyOf, ok := items["anyOf"].([]any)
+	if !ok || len(anyOf) != 2 {
+		t.Fatalf("expected installs items (a *ToolInstall) to be wrapped in anyOf to allow null, got %v", items)
+	}
+	ref := anyOf[0].(map[string]any)
+	if ref["$ref"] != "#/$defs/ToolInstall" {
+		t.Errorf("expected installs items to $ref ToolInstall, got %v", ref["$ref"])
+	}
+	null := anyOf[1].(map[string]any)
+	if null["type"] != "null" {
+		t.Errorf("expected installs items to also accept null, got %v", null)
+	}
+}
+
+func TestJSONSchemaRenderer_EmbeddedFieldsPromoted(t *testing.T) {
+	tmpdir := t.TempDir()
+	initTempModule(t, tmpdir)
+	source := `package testdata
+type Meta struct {
+	// Common field
+	ID string ` + "`yaml:\"id\"`" + `
+}
+type FullItem struct {
+	Meta
+	CustomName string ` + "`yaml:\"custom_name\"`" + `
+}`
+	writeTempGoFile(t, tmpdir, "embedded.go", source)
+
+	p := NewParser()
+	if err := p.Load(tmpdir, "", true); err != nil {
+		t.Fatal(err)
+	}
+
+	item := p.structs[testdataPkg+".FullItem"]
+	var buf bytes.Buffer
+	if err := (JSONSchemaRenderer{}).Render(&buf, p, item); err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	defs := doc["$defs"].(map[string]any)
+	props := defs["FullItem"].(map[string]any)["properties"].(map[string]any)
+
+	if _, ok := props["id"]; !ok {
+		t.Errorf("expected embedded Meta's id field to be promoted into FullItem's properties, got %v", props)
+	}
+	if _, ok := props["custom_name"]; !ok {
+		t.Errorf("expected custom_name field, got %v", props)
+	}
+}
+
+func TestJSONSchemaRenderer_PrefersJSONTag(t *testing.T) {
+	tmpdir := t.TempDir()
+	initTempModule(t, tmpdir)
+	source := `package testdata
+type Config struct {
+	Name string ` + "`yaml:\"display_name\" json:\"name\"`" + `
+}`
+	writeTempGoFile(t, tmpdir, "config.go", source)
+
+	p := NewParser()
+	if err := p.Load(tmpdir, "", true); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := p.structs[testdataPkg+".Config"]
+	var buf bytes.Buffer
+	if err := (JSONSchemaRenderer{}).Render(&buf, p, cfg); err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	props := doc["$defs"].(map[string]any)["Config"].(map[string]any)["properties"].(map[string]any)
+	if _, ok := props["name"]; !ok {
+		t.Errorf("expected JSON Schema to key the field by its json tag, got %v", props)
+	}
+	if _, ok := props["display_name"]; ok {
+		t.Errorf("expected JSON Schema not to use the yaml tag when a json tag is present, got %v", props)
+	}
+}
+
+func TestJSONSchemaRenderer_Markers(t *testing.T) {
+	tmpdir := t.TempDir()
+	initTempModule(t, tmpdir)
+	source := `package testdata
+type Config struct {
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=1
+	Replicas int ` + "`json:\"replicas\"`" + `
+	// +kubebuilder:validation:Enum=dev;staging;prod
+	Mode string ` + "`json:\"mode\"`" + `
+	// +kubebuilder:validation:Required
+	Name string ` + "`json:\"name\"`" + `
+}`
+	writeTempGoFile(t, tmpdir, "config.go", source)
+
+	p := NewParser()
+	if err := p.Load(tmpdir, "", true); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := p.structs[testdataPkg+".Config"]
+	var buf bytes.Buffer
+	if err := (JSONSchemaRenderer{}).Render(&buf, p, cfg); err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	def := doc["$defs"].(map[string]any)["Config"].(map[string]any)
+	props := def["properties"].(map[string]any)
+
+	replicas := props["replicas"].(map[string]any)
+	if replicas["default"] != float64(3) {
+		t.Errorf("expected default 3 (converted to integer type), got %v (%T)", replicas["default"], replicas["default"])
+	}
+	if replicas["minimum"] != float64(1) {
+		t.Errorf("expected minimum 1, got %v", replicas["minimum"])
+	}
+
+	mode := props["mode"].(map[string]any)
+	enum, ok := mode["enum"].([]any)
+	if !ok || len(enum) != 3 || enum[0] != "dev" {
+		t.Errorf("expected enum [dev staging prod], got %v", mode["enum"])
+	}
+
+	required, ok := def["required"].([]any)
+	if !ok || len(required) != 1 || required[0] != "name" {
+		t.Errorf("expected required [name], got %v", def["required"])
+	}
+}
+
+// TestJSONSchemaRenderer_PointerFieldAcceptsNull guards against regressing to
+// the OpenAPI-only "nullable" keyword, which draft 2020-12 validators ignore:
+// a pointer field's schema must accept an explicit null the same way a Go
+// pointer accepts nil, verified here against a real draft 2020-12 validator
+// rather than just inspecting the emitted map.
+func TestJSONSchemaRenderer_PointerFieldAcceptsNull(t *testing.T) {
+	tmpdir := t.TempDir()
+	initTempModule(t, tmpdir)
+	source := `package testdata
+type Config struct {
+	// Name an optional display name
+	Name *string ` + "`json:\"name\"`" + `
+}`
+	writeTempGoFile(t, tmpdir, "config.go", source)
+
+	p := NewParser()
+	if err := p.Load(tmpdir, "", true); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := p.structs[testdataPkg+".Config"]
+	var buf bytes.Buffer
+	if err := (JSONSchemaRenderer{}).Render(&buf, p, cfg); err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource("config.json", bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatalf("failed to add schema resource: %v", err)
+	}
+	schema, err := compiler.Compile("config.json")
+	if err != nil {
+		t.Fatalf("failed to compile schema: %v\n%s", err, buf.String())
+	}
+
+	if err := schema.Validate(map[string]any{"name": nil}); err != nil {
+		t.Errorf("expected a null name to validate against a *string field's schema, got: %v\n%s", err, buf.String())
+	}
+	if err := schema.Validate(map[string]any{"name": "ok"}); err != nil {
+		t.Errorf("expected a string name to still validate, got: %v", err)
+	}
+}
+
+// TestJSONSchemaRenderer_BracketedMapKeyPreservesValueType guards against
+// mapValueType's "first ']'" bug losing the value type whenever the map's
+// own key type display contains a bracket (e.g. an array key type).
+func TestJSONSchemaRenderer_BracketedMapKeyPreservesValueType(t *testing.T) {
+	tmpdir := t.TempDir()
+	initTempModule(t, tmpdir)
+	source := `package testdata
+type Config struct {
+	// Grid a map keyed by a bracketed array type
+	Grid map[[2]int]string ` + "`json:\"grid\"`" + `
+}`
+	writeTempGoFile(t, tmpdir, "config.go", source)
+
+	p := NewParser()
+	if err := p.Load(tmpdir, "", true); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := p.structs[testdataPkg+".Config"]
+	var buf bytes.Buffer
+	if err := (JSONSchemaRenderer{}).Render(&buf, p, cfg); err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	props := doc["$defs"].(map[string]any)["Config"].(map[string]any)["properties"].(map[string]any)
+	grid := props["grid"].(map[string]any)
+	additional, ok := grid["additionalProperties"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected additionalProperties object, got %v", grid["additionalProperties"])
+	}
+	if additional["type"] != "string" {
+		t.Errorf("expected additionalProperties type string, got %v", additional["type"])
+	}
+}
@@ -0,0 +1,143 @@
+package structdocs
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// Renderer turns a parsed StructInfo into documentation written to w.
+// Implementations may recurse into nested struct fields found via p.
+type Renderer interface {
+	Render(w io.Writer, p *Parser, s StructInfo) error
+}
+
+// YAMLRenderer renders structs as commented YAML examples, recursing into
+// nested struct fields.
+type YAMLRenderer struct{}
+
+// Render writes s's header and an example YAML document to w.
+func (YAMLRenderer) Render(w io.Writer, p *Parser, s StructInfo) error {
+	printStructHeader(w, s)
+	printYAML(w, p, s, 0, s.key())
+	fmt.Fprintln(w)
+	return nil
+}
+
+func printStructHeader(w io.Writer, s StructInfo) {
+	rel, _ := filepath.Rel(".", s.FilePath)
+	fmt.Fprintf(w, "### %s#%s\n\n", rel, s.Name)
+
+	if s.Description != "" {
+		fmt.Fprintf(w, "%s\n\n", s.Description)
+	}
+}
+
+func printYAML(w io.Writer, p *Parser, s StructInfo, level int, seen ...string) {
+	indent := strings.Repeat("  ", level)
+
+	// track seen types for recursion guard
+	seenMap := make(map[string]bool, len(seen))
+	for _, v := range seen {
+		seenMap[v] = true
+	}
+
+	for _, f := range s.Fields {
+		if f.Description != "" {
+			fmt.Fprintf(w, "%s# %s\n", indent, f.Description)
+		}
+		printMarkerComments(w, indent, f.Markers)
+		name := f.Tags["yaml"]
+		if name == "" {
+			name = f.Tag
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+
+		displayType := fmt.Sprintf("<%s>", f.Type)
+
+		if strings.HasPrefix(f.Type, "[]") {
+			fmt.Fprintf(w, "%s%s: <%s>\n", indent, name, f.Type)
+			if nested, ok := findStruct(p, f.elemKey); ok && len(nested.Fields) > 0 {
+				// avoid cycles for slices too
+				if seenMap[nested.key()] {
+					fmt.Fprintf(w, "%s  # (recursive reference to %s)\n", indent, nested.Name)
+				} else {
+					fmt.Fprintf(w, "%s-\n", indent)
+					printYAML(w, p, nested, level+1, append(seen, nested.key())...)
+				}
+			}
+			continue
+		}
+
+		if strings.HasPrefix(f.Type, "map[") {
+			fmt.Fprintf(w, "%s%s: <%s>\n", indent, name, f.Type)
+			if nested, ok := findStruct(p, f.elemKey); ok && len(nested.Fields) > 0 {
+				if seenMap[nested.key()] {
+					fmt.Fprintf(w, "%s  # (recursive reference to %s)\n", indent, nested.Name)
+				} else {
+					fmt.Fprintf(w, "%s  key: <%s>\n", indent, mapValueType(f.Type))
+					printYAML(w, p, nested, level+2, append(seen, nested.key())...)
+				}
+			} else {
+				fmt.Fprintf(w, "%s  key: <%s>\n", indent, mapValueType(f.Type))
+			}
+			continue
+		}
+
+		if nested, ok := findStruct(p, f.elemKey); ok && len(nested.Fields) > 0 {
+			fmt.Fprintf(w, "%s%s: %s\n", indent, name, displayType)
+			if seenMap[nested.key()] {
+				fmt.Fprintf(w, "%s  # (recursive reference to %s)\n", indent, nested.Name)
+			} else {
+				printYAML(w, p, nested, level+1, append(seen, nested.key())...)
+			}
+		} else {
+			fmt.Fprintf(w, "%s%s: %s\n", indent, name, displayType)
+		}
+	}
+}
+
+// markerCommentOrder fixes the order marker-derived comments are rendered
+// in, so output is stable regardless of doc comment marker order.
+var markerCommentOrder = []string{"required", "default", "example", "enum", "minimum", "maximum"}
+
+// printMarkerComments renders the recognized "+name=value" doc comment
+// markers (default, example, enum, minimum, maximum, required) as extra
+// "# name: value" comment lines above a field's example entry.
+func printMarkerComments(w io.Writer, indent string, markers map[string][]string) {
+	for _, name := range markerCommentOrder {
+		vals, ok := markers[name]
+		if !ok {
+			continue
+		}
+		if len(vals) == 0 {
+			fmt.Fprintf(w, "%s# %s\n", indent, name)
+		} else {
+			fmt.Fprintf(w, "%s# %s: %s\n", indent, name, strings.Join(vals, ", "))
+		}
+	}
+}
+
+// mapValueType extracts the "V" portion of a "map[K]V" display string,
+// tracking bracket depth to find the "]" that closes the "map[" opener
+// rather than just the first one, since K's own display may contain
+// brackets (e.g. "map[[2]int]string" or a generic "map[Box[string]]int").
+func mapValueType(display string) string {
+	rest := strings.TrimPrefix(display, "map[")
+	depth := 1
+	for i, r := range rest {
+		switch r {
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return rest[i+1:]
+			}
+		}
+	}
+	return rest
+}
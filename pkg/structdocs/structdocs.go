@@ -0,0 +1,502 @@
+// Package structdocs parses Go struct declarations and renders documentation
+// for them (YAML examples, JSON Schema, etc.) so that the logic can be
+// reused by tools other than the struct-to-docs CLI.
+package structdocs
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// defaultTagPriority is used when Parser isn't given an explicit tag list:
+// the first of these tags present on a field wins as its primary name.
+var defaultTagPriority = []string{"yaml", "json", "toml"}
+
+// StructInfo describes a single parsed struct declaration.
+type StructInfo struct {
+	Name        string
+	Description string
+	Fields      []FieldInfo
+	Package     string // short package name, e.g. "structdocs"
+	PkgPath     string // fully-qualified import path, e.g. "github.com/joy-dx/struct-to-docs/pkg/structdocs"
+	FilePath    string
+}
+
+// key returns the fully-qualified PkgPath.Name this struct is indexed under.
+func (s StructInfo) key() string {
+	return s.PkgPath + "." + s.Name
+}
+
+// FieldInfo describes a single field within a parsed struct.
+type FieldInfo struct {
+	Name        string
+	Description string
+	Tag         string              // primary wire name, the first of Parser's tag priority found on this field
+	Tags        map[string]string   // recognized struct tag values, keyed by tag name (e.g. "yaml", "json", "toml")
+	Markers     map[string][]string // "+name=value" doc comment markers, keyed by the lowercased last colon-separated part of their name (e.g. +kubebuilder:default=5 as "default")
+	Type        string
+	Embedded    bool
+
+	// elemKey is the fully-qualified PkgPath.Name of the struct this field's
+	// type (after unwrapping pointers, slices, arrays and map values) refers
+	// to, if any. Empty when that type isn't a named struct type. It is set
+	// even for structs outside the packages this Parser has indexed, which
+	// findStruct then reports as not found.
+	elemKey string
+}
+
+// Parser loads Go packages from disk and indexes the structs it finds, keyed
+// by their fully-qualified PkgPath.Name.
+type Parser struct {
+	structs     map[string]StructInfo
+	tagPriority []string
+}
+
+// NewParser returns an empty Parser ready to Load packages into. tags sets
+// the struct tag names to read for field names, in priority order; it
+// defaults to "yaml", "json", "toml" when omitted.
+func NewParser(tags ...string) *Parser {
+	if len(tags) == 0 {
+		tags = defaultTagPriority
+	}
+	return &Parser{structs: make(map[string]StructInfo), tagPriority: tags}
+}
+
+// Structs returns the parsed structs, keyed by fully-qualified PkgPath.Name.
+func (p *Parser) Structs() map[string]StructInfo {
+	return p.structs
+}
+
+// Select returns the parsed structs matching pattern, sorted by their
+// fully-qualified key. An empty pattern selects everything. Patterns may
+// match either the fully-qualified key or the bare struct name.
+func (p *Parser) Select(pattern string) ([]StructInfo, error) {
+	keys := sortedKeys(p.structs)
+
+	var selected []StructInfo
+	if pattern == "" {
+		for _, k := range keys {
+			selected = append(selected, p.structs[k])
+		}
+		return selected, nil
+	}
+
+	for _, k := range keys {
+		s := p.structs[k]
+		if matchStructPattern(k, pattern) || matchStructPattern(s.Name, pattern) {
+			selected = append(selected, s)
+		}
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no structs matched pattern '%s'", pattern)
+	}
+	return selected, nil
+}
+
+// --- Package Loading / AST Parsing ---
+
+// Load parses every package of the Go module containing dir (the module
+// whose go.mod is found by walking upward from dir), recording each type
+// declaration it finds. If dirFilter is non-empty, only files whose
+// directory matches the pattern are processed.
+func (p *Parser) Load(dir, dirFilter string, allowCreate bool) error {
+	// Try to locate go.mod from dir upward
+	modPath, found := findGoModUp(dir)
+	if !found {
+		if allowCreate {
+			tempPath := filepath.Join(dir, "go.mod")
+			if err := os.WriteFile(
+				tempPath,
+				[]byte("module tempmod\n\ngo 1.21\n"),
+				0600,
+			); err != nil {
+				return fmt.Errorf("failed to create temporary go.mod: %w", err)
+			}
+			modPath = tempPath
+		} else {
+			return fmt.Errorf("no go.mod found above '%s'; use -allow-create-go-mod for tests", dir)
+		}
+	}
+
+	modDir := filepath.Dir(modPath)
+
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedSyntax | packages.NeedFiles |
+			packages.NeedTypes | packages.NeedTypesInfo | packages.NeedDeps | packages.NeedImports,
+		Dir:   modDir,
+		Tests: false,
+	}
+	pkgs, err := packages.Load(cfg, "./...")
+	if err != nil {
+		return err
+	}
+	for _, pkg := range pkgs {
+		for i, file := range pkg.Syntax {
+			path := pkg.GoFiles[i]
+			include := true
+			if dirFilter != "" {
+				absPath, _ := filepath.Abs(filepath.Dir(path))
+				include = matchStructPattern(absPath, dirFilter)
+			}
+			if include {
+				p.processFile(pkg, file, path)
+			}
+		}
+	}
+	return nil
+}
+
+func (p *Parser) processFile(pkg *packages.Package, file *ast.File, path string) {
+	ast.Inspect(file, func(n ast.Node) bool {
+		gen, ok := n.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			return true
+		}
+		for _, spec := range gen.Specs {
+			typeSpec, ok := spec.(*ast.TypeSpec)
+			if !ok {
+				continue
+			}
+			structName := typeSpec.Name.Name
+			desc := extractDoc(gen.Doc, typeSpec.Doc)
+			desc = cleanStructDoc(structName, desc)
+			info := StructInfo{
+				Name:        structName,
+				Description: desc,
+				Package:     pkg.Name,
+				PkgPath:     pkg.PkgPath,
+				FilePath:    path,
+			}
+			if st, ok := typeSpec.Type.(*ast.StructType); ok {
+				for _, f := range st.Fields.List {
+					desc, markers := parseFieldMarkers(f.Doc)
+					tag, tags := parseFieldTag(f.Tag, p.tagPriority)
+					fieldGoType := pkg.TypesInfo.TypeOf(f.Type)
+					fieldType, elemKey := resolveType(fieldGoType, pkg.PkgPath)
+
+					if len(f.Names) == 0 {
+						// Anonymous embedded field
+						info.Fields = append(info.Fields, FieldInfo{
+							Name:        embeddedFieldName(fieldGoType, fieldType),
+							Description: desc,
+							Tag:         tag,
+							Tags:        tags,
+							Markers:     markers,
+							Type:        fieldType,
+							Embedded:    true,
+							elemKey:     elemKey,
+						})
+						continue
+					}
+
+					for _, name := range f.Names {
+						info.Fields = append(info.Fields, FieldInfo{
+							Name:        name.Name,
+							Description: desc,
+							Tag:         tag,
+							Tags:        tags,
+							Markers:     markers,
+							Type:        fieldType,
+							Embedded:    false,
+							elemKey:     elemKey,
+						})
+					}
+				}
+			}
+			p.structs[info.key()] = info
+		}
+		return true
+	})
+}
+
+// --- Utilities ---
+
+func sortedKeys(m map[string]StructInfo) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func matchStructPattern(name, pattern string) bool {
+	if !strings.ContainsAny(pattern, "*?") {
+		return name == pattern
+	}
+
+	var re strings.Builder
+	re.WriteString("^")
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			re.WriteString(".*")
+		case '?':
+			re.WriteString(".")
+		default:
+			re.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	re.WriteString("$")
+
+	ok, err := regexp.MatchString(re.String(), name)
+	return err == nil && ok
+}
+
+func extractDoc(groups ...*ast.CommentGroup) string {
+	var lines []string
+	for _, g := range groups {
+		if g == nil {
+			continue
+		}
+		for _, c := range g.List {
+			txt := strings.TrimSpace(strings.TrimPrefix(c.Text, "//"))
+			if txt != "" {
+				lines = append(lines, txt)
+			}
+		}
+	}
+	return strings.Join(lines, " ")
+}
+
+// markerLineRe matches a "+kubebuilder:validation:Enum=a;b;c"-style doc
+// comment marker line: a leading "+", a colon-separated name, and an
+// optional "=value" (semicolon-separated for multi-valued markers).
+var markerLineRe = regexp.MustCompile(`^\+([A-Za-z0-9_:.-]+)(?:=(.*))?$`)
+
+// parseFieldMarkers extracts "+name[:key=value...]" markers (e.g.
+// Kubernetes' "+kubebuilder:default=5") from a field's doc comment, returning
+// the remaining description text with marker lines stripped out, plus the
+// markers themselves keyed by their lowercased, colon-stripped name (so
+// "+kubebuilder:validation:Enum=a;b;c" becomes Markers["enum"]).
+func parseFieldMarkers(doc *ast.CommentGroup) (desc string, markers map[string][]string) {
+	markers = make(map[string][]string)
+	if doc == nil {
+		return "", markers
+	}
+	var lines []string
+	for _, c := range doc.List {
+		txt := strings.TrimSpace(strings.TrimPrefix(c.Text, "//"))
+		if txt == "" {
+			continue
+		}
+		if m := markerLineRe.FindStringSubmatch(txt); m != nil {
+			name := markerName(m[1])
+			if m[2] == "" {
+				markers[name] = []string{}
+			} else {
+				markers[name] = strings.Split(m[2], ";")
+			}
+			continue
+		}
+		lines = append(lines, txt)
+	}
+	return strings.Join(lines, " "), markers
+}
+
+func markerName(raw string) string {
+	parts := strings.Split(raw, ":")
+	return strings.ToLower(parts[len(parts)-1])
+}
+
+// parseFieldTag reads tag, recording the value of each tag name in priority
+// that's present on the field. It returns the value of the first one found
+// (tag's "primary" name) alongside the full set, so renderers for other
+// formats (e.g. JSON Schema reading "json") can pick a different one.
+func parseFieldTag(tag *ast.BasicLit, priority []string) (primary string, tags map[string]string) {
+	if tag == nil {
+		return "", nil
+	}
+	raw, err := strconv.Unquote(tag.Value)
+	if err != nil {
+		return "", nil
+	}
+	st := reflect.StructTag(raw)
+
+	tags = make(map[string]string)
+	for _, name := range priority {
+		if v, ok := st.Lookup(name); ok {
+			tags[name] = strings.Split(v, ",")[0]
+		}
+	}
+	if len(tags) == 0 {
+		return "", nil
+	}
+	for _, name := range priority {
+		if v, ok := tags[name]; ok {
+			return v, tags
+		}
+	}
+	return "", tags
+}
+
+// resolveType renders t as it should appear in generated docs, and, when t
+// (after unwrapping pointers, slices, arrays and map values) names a struct
+// type, returns that
+// struct's fully-qualified PkgPath.Name so callers can look it up in
+// Parser.structs. curPkgPath is the import path of the package the field is
+// declared in, used to decide whether a named type needs a package qualifier.
+func resolveType(t types.Type, curPkgPath string) (display string, elemKey string) {
+	if t == nil {
+		return "unknown", ""
+	}
+	switch t := t.(type) {
+	case *types.Named:
+		obj := t.Obj()
+		name := obj.Name()
+		if targs := t.TypeArgs(); targs != nil && targs.Len() > 0 {
+			args := make([]string, targs.Len())
+			for i := 0; i < targs.Len(); i++ {
+				args[i], _ = resolveType(targs.At(i), curPkgPath)
+			}
+			name += "[" + strings.Join(args, ", ") + "]"
+		}
+		if obj.Pkg() == nil {
+			// Universe-scope named type (e.g. error).
+			return name, ""
+		}
+		// elemKey always refers to the generic struct's own declaration, not
+		// a particular instantiation, so it stays keyed on the bare name.
+		key := obj.Pkg().Path() + "." + obj.Name()
+		if _, isStruct := t.Underlying().(*types.Struct); !isStruct {
+			key = ""
+		}
+		if obj.Pkg().Path() == curPkgPath {
+			return name, key
+		}
+		return obj.Pkg().Name() + "." + name, key
+	case *types.Pointer:
+		inner, key := resolveType(t.Elem(), curPkgPath)
+		return "*" + inner, key
+	case *types.Slice:
+		inner, key := resolveType(t.Elem(), curPkgPath)
+		return "[]" + inner, key
+	case *types.Array:
+		inner, key := resolveType(t.Elem(), curPkgPath)
+		return fmt.Sprintf("[%d]%s", t.Len(), inner), key
+	case *types.Map:
+		keyDisp, _ := resolveType(t.Key(), curPkgPath)
+		valDisp, valKey := resolveType(t.Elem(), curPkgPath)
+		return fmt.Sprintf("map[%s]%s", keyDisp, valDisp), valKey
+	case *types.Chan:
+		inner, _ := resolveType(t.Elem(), curPkgPath)
+		switch t.Dir() {
+		case types.SendOnly:
+			return "chan<- " + inner, ""
+		case types.RecvOnly:
+			return "<-chan " + inner, ""
+		default:
+			return "chan " + inner, ""
+		}
+	case *types.Signature:
+		params := make([]string, t.Params().Len())
+		for i := 0; i < t.Params().Len(); i++ {
+			params[i], _ = resolveType(t.Params().At(i).Type(), curPkgPath)
+		}
+		results := make([]string, t.Results().Len())
+		for i := 0; i < t.Results().Len(); i++ {
+			results[i], _ = resolveType(t.Results().At(i).Type(), curPkgPath)
+		}
+		sig := "func(" + strings.Join(params, ", ") + ")"
+		switch len(results) {
+		case 0:
+		case 1:
+			sig += " " + results[0]
+		default:
+			sig += " (" + strings.Join(results, ", ") + ")"
+		}
+		return sig, ""
+	case *types.Interface:
+		if t.NumMethods() == 0 {
+			return "interface{}", ""
+		}
+		methods := make([]string, t.NumMethods())
+		for i := 0; i < t.NumMethods(); i++ {
+			methods[i] = t.Method(i).Name() + "()"
+		}
+		sort.Strings(methods)
+		return "interface{" + strings.Join(methods, "; ") + "}", ""
+	case *types.TypeParam:
+		return t.Obj().Name(), ""
+	case *types.Basic:
+		return t.Name(), ""
+	default:
+		return "unknown", ""
+	}
+}
+
+// embeddedFieldName returns the lowercased form of the Go spec's implicit
+// name for an anonymous (embedded) field: the embedded type's own bare name,
+// lowercased, ignoring any pointer
+// wrapper, package qualifier, or generic type arguments (so an embedded
+// "Box[string]" promotes as "box", not "box[string]"). t is unwrapped
+// directly via go/types rather than string-mangling fieldType, its
+// rendered display form, since that form isn't guaranteed to round-trip
+// cleanly (e.g. generics). fieldType is used only as a defensive fallback
+// for the types.Type shapes Go doesn't actually allow to be embedded.
+func embeddedFieldName(t types.Type, fieldType string) string {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	if named, ok := t.(*types.Named); ok {
+		return strings.ToLower(named.Obj().Name())
+	}
+	typ := strings.TrimPrefix(fieldType, "*")
+	parts := strings.Split(typ, ".")
+	return strings.ToLower(parts[len(parts)-1])
+}
+
+func findStruct(p *Parser, key string) (StructInfo, bool) {
+	if key == "" {
+		return StructInfo{}, false
+	}
+	s, ok := p.structs[key]
+	return s, ok
+}
+
+// findGoModUp walks upward from the given directory until it finds a go.mod file.
+// Returns the path to the go.mod and whether it was found.
+func findGoModUp(start string) (string, bool) {
+	dir, err := filepath.Abs(start)
+	if err != nil {
+		return "", false
+	}
+	for {
+		modPath := filepath.Join(dir, "go.mod")
+		if _, err := os.Stat(modPath); err == nil {
+			return modPath, true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break // reached filesystem root
+		}
+		dir = parent
+	}
+	return "", false
+}
+
+func cleanStructDoc(structName, doc string) string {
+	doc = strings.TrimSpace(doc)
+	if strings.HasPrefix(doc, structName) {
+		rest := strings.TrimSpace(strings.TrimPrefix(doc, structName))
+		if rest != "" {
+			firstRune := []rune(rest)[0]
+			// Trim only if next word starts with uppercase (description style)
+			if firstRune >= 'A' && firstRune <= 'Z' {
+				return strings.TrimSpace(rest)
+			}
+		}
+	}
+	return doc
+}
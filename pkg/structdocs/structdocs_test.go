@@ -0,0 +1,559 @@
+package structdocs
+
+import (
+	"bytes"
+	"go/ast"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// --- Helpers -------------------------------------------------------
+
+// testdataPkg is the module/package path used by initTempModule, so tests
+// can build the fully-qualified PkgPath.Name keys Parser.structs is indexed by.
+const testdataPkg = "example.com/testdata"
+
+func initTempModule(t *testing.T, dir string) {
+	t.Helper()
+	mod := []byte("module example.com/testdata\n\ngo 1.21\n")
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), mod, 0600); err != nil {
+		t.Fatalf("failed to write go.mod: %v", err)
+	}
+}
+
+func writeTempGoFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("failed writing temp Go file: %v", err)
+	}
+	return path
+}
+
+// --- Example Struct Definitions -----------------------------------
+
+const exampleStructs = `package testdata
+
+// Tool describes a software tool definition
+type Tool struct {
+	// Name Human readable title representing the tool name
+	Name string ` + "`yaml:\"name\"`" + `
+	// Ref A human readable but, machine safe identifier for the tool
+	Ref ToolRef ` + "`yaml:\"ref\"`" + `
+	// Summary a short description highlighting the purpose of the tool
+	Summary string ` + "`yaml:\"summary\"`" + `
+	// Description Long form description of the tool
+	Description string ` + "`yaml:\"description\"`" + `
+	// HomePage URL pointing to the official home page of the tool
+	HomePage string ` + "`yaml:\"home_page\"`" + `
+	// IconURL Custom URL pointing to an image that can be used where icons are presented to the user
+	IconURL string ` + "`yaml:\"icon_url\"`" + `
+	// License URL pointing to the tool license agreement
+	License string ` + "`yaml:\"license\"`" + `
+	// Dependencies A slice of tool references that need to also be available before tool installation / use
+	Dependencies []ToolRef ` + "`yaml:\"dependencies\"`" + `
+	// Binaries A slice of path references to executable files that should be made available to the environments
+	Binaries []string ` + "`yaml:\"binaries\"`" + `
+	// Environment System environment variable declarations to be included at operating time
+	Environment []string ` + "`yaml:\"environment\"`" + `
+	// Tags A slice of custom taxonomy that can be used to characterise the tool
+	Tags []string ` + "`yaml:\"tags\"`" + `
+	// Installs collection of install records letting the system know what is available for reuse
+	Installs []*ToolInstall ` + "`yaml:\"installs\"`" + `
+}
+
+// ToolRef represents a unique reference identifier for tools
+type ToolRef string
+
+// ToolInstall represents a single installation record
+type ToolInstall struct {
+	// ToolRef A human readable but machine safe identifier for the tool
+	ToolRef string ` + "`yaml:\"tool_ref\"`" + `
+	// Version Semantic version string
+	Version string ` + "`yaml:\"version\"`" + `
+	// Platform What operating system this release record is relevant to
+	Platform string ` + "`yaml:\"platform\"`" + `
+}
+`
+
+// --- Tests --------------------------------------------------------
+
+func TestParser_Load_SimpleStructs(t *testing.T) {
+	tmpdir := t.TempDir()
+	initTempModule(t, tmpdir)
+	writeTempGoFile(t, tmpdir, "tool.go", exampleStructs)
+
+	p := NewParser()
+	if err := p.Load(tmpdir, "", true); err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+
+	// Basic checks
+	if len(p.structs) == 0 {
+		t.Fatalf("expected some structs, got none")
+	}
+	wantStructs := []string{"Tool", "ToolRef", "ToolInstall"}
+	for _, w := range wantStructs {
+		if _, ok := p.structs[testdataPkg+"."+w]; !ok {
+			t.Errorf("expected struct %s to be parsed", w)
+		}
+	}
+}
+
+func TestParser_ExtractsDocsAndTags(t *testing.T) {
+	tmpdir := t.TempDir()
+	initTempModule(t, tmpdir)
+	writeTempGoFile(t, tmpdir, "tool.go", exampleStructs)
+	p := NewParser()
+	if err := p.Load(tmpdir, "", true); err != nil {
+		t.Fatal(err)
+	}
+
+	tool := p.structs[testdataPkg+".Tool"]
+	if tool.Description != "Tool describes a software tool definition" {
+		t.Errorf("unexpected struct doc: %q", tool.Description)
+	}
+	foundName := false
+	for _, f := range tool.Fields {
+		if f.Name == "Name" {
+			foundName = true
+			if !strings.Contains(f.Description, "Human readable title") {
+				t.Errorf("expected doc comment for Name field, got %q", f.Description)
+			}
+			if f.Tag != "name" {
+				t.Errorf("expected primary tag 'name', got %q", f.Tag)
+			}
+			if f.Tags["yaml"] != "name" {
+				t.Errorf("expected yaml tag 'name', got %q", f.Tags["yaml"])
+			}
+		}
+	}
+	if !foundName {
+		t.Error("Name field not found")
+	}
+}
+
+func TestParser_RecursiveYAMLPrinting(t *testing.T) {
+	tmpdir := t.TempDir()
+	initTempModule(t, tmpdir)
+	writeTempGoFile(t, tmpdir, "tool.go", exampleStructs)
+
+	p := NewParser()
+	if err := p.Load(tmpdir, "", true); err != nil {
+		t.Fatal(err)
+	}
+
+	tool := p.structs[testdataPkg+".Tool"]
+	var buf bytes.Buffer
+	renderer := YAMLRenderer{}
+	if err := renderer.Render(&buf, p, tool); err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	out := buf.String()
+
+	// Verify nested ToolInstall expansion
+	if !strings.Contains(out, "tool_ref: <string>") {
+		t.Errorf("expected nested ToolInstall content in output:\n%s", out)
+	}
+	if !strings.Contains(out, "dependencies: <[]ToolRef>") {
+		t.Errorf("expected slice field in output:\n%s", out)
+	}
+}
+
+func TestMatchStructPattern(t *testing.T) {
+	tests := []struct {
+		name, pattern string
+		want          bool
+	}{
+		{"Tool", "Tool", true},
+		{"ToolInstall", "Tool*", true},
+		{"ToolInstall", "?ool*", true},
+		{"Random", "Tool*", false},
+	}
+	for _, tt := range tests {
+		if got := matchStructPattern(tt.name, tt.pattern); got != tt.want {
+			t.Errorf("matchStructPattern(%q,%q)=%v, want %v", tt.name, tt.pattern, got, tt.want)
+		}
+	}
+}
+
+func TestMapValueType(t *testing.T) {
+	tests := []struct {
+		display string
+		want    string
+	}{
+		{"map[string]string", "string"},
+		{"map[string]*ToolInstall", "*ToolInstall"},
+		{"map[[2]int]string", "string"},
+		{"map[Box[string]]int", "int"},
+	}
+	for _, tt := range tests {
+		if got := mapValueType(tt.display); got != tt.want {
+			t.Errorf("mapValueType(%q)=%q, want %q", tt.display, got, tt.want)
+		}
+	}
+}
+
+func TestEmbeddedStructHandling(t *testing.T) {
+	tmpdir := t.TempDir()
+	initTempModule(t, tmpdir)
+	source := `package testdata
+type Meta struct {
+	// Common field
+	ID string ` + "`yaml:\"id\"`" + `
+}
+type FullItem struct {
+	// Embedded metadata
+	Meta
+	// CustomName Another field
+	CustomName string ` + "`yaml:\"custom_name\"`" + `
+}`
+	writeTempGoFile(t, tmpdir, "embedded.go", source)
+
+	p := NewParser()
+	err := p.Load(tmpdir, "", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item := p.structs[testdataPkg+".FullItem"]
+	foundEmbedded := false
+	for _, f := range item.Fields {
+		if f.Embedded && f.Name == "meta" {
+			foundEmbedded = true
+		}
+	}
+	if !foundEmbedded {
+		t.Error("expected embedded field detected")
+	}
+}
+
+func TestEmbeddedGenericFieldHandling(t *testing.T) {
+	tmpdir := t.TempDir()
+	initTempModule(t, tmpdir)
+	source := `package testdata
+type Box[T any] struct {
+	// Value the boxed value
+	Value T ` + "`yaml:\"value\"`" + `
+}
+type Foo struct {
+	Box[string]
+	// Name Another field
+	Name string ` + "`yaml:\"name\"`" + `
+}`
+	writeTempGoFile(t, tmpdir, "generic_embedded.go", source)
+
+	p := NewParser()
+	if err := p.Load(tmpdir, "", true); err != nil {
+		t.Fatal(err)
+	}
+
+	foo := p.structs[testdataPkg+".Foo"]
+	foundEmbedded := false
+	for _, f := range foo.Fields {
+		if f.Embedded {
+			foundEmbedded = true
+			if f.Name != "box" {
+				t.Errorf("expected embedded Box[string] to promote as 'box', got %q", f.Name)
+			}
+		}
+	}
+	if !foundEmbedded {
+		t.Error("expected embedded field detected")
+	}
+}
+
+// Clean up or coverage for edge cases
+func TestParseFieldTag(t *testing.T) {
+	tag := &ast.BasicLit{Value: "`json:\"foo\" toml:\"bar\"`"}
+
+	primary, tags := parseFieldTag(tag, []string{"yaml", "json", "toml"})
+	if primary != "foo" {
+		t.Errorf("expected primary tag to fall back to json 'foo', got %q", primary)
+	}
+	if tags["json"] != "foo" || tags["toml"] != "bar" {
+		t.Errorf("expected both json and toml tags recorded, got %+v", tags)
+	}
+	if _, ok := tags["yaml"]; ok {
+		t.Errorf("expected no yaml tag recorded, got %+v", tags)
+	}
+
+	if primary, tags := parseFieldTag(nil, []string{"yaml"}); primary != "" || tags != nil {
+		t.Errorf("expected empty result for a nil tag, got %q, %+v", primary, tags)
+	}
+}
+
+func TestResolveType(t *testing.T) {
+	got, key := resolveType(types.Typ[types.String], "example.com/testdata")
+	if got != "string" {
+		t.Errorf("expected string, got %q", got)
+	}
+	if key != "" {
+		t.Errorf("expected no struct key for a basic type, got %q", key)
+	}
+}
+
+func TestStructDocPrinted(t *testing.T) {
+	tmpdir := t.TempDir()
+	initTempModule(t, tmpdir)
+
+	source := `package testdata
+// CoreArchiveExtractConfig Decompress a wide variety of archives
+type CoreArchiveExtractConfig struct {
+	Ref string ` + "`yaml:\"ref\"`" + `
+}`
+	writeTempGoFile(t, tmpdir, "archive.go", source)
+
+	p := NewParser()
+	if err := p.Load(tmpdir, "", true); err != nil {
+		t.Fatal(err)
+	}
+
+	s := p.structs[testdataPkg+".CoreArchiveExtractConfig"]
+	var buf bytes.Buffer
+	renderer := YAMLRenderer{}
+	if err := renderer.Render(&buf, p, s); err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	out := buf.String()
+
+	if strings.Contains(out, "CoreArchiveExtractConfig Decompress") {
+		t.Errorf("struct name should have been trimmed from description:\n%s", out)
+	}
+	if !strings.Contains(out, "Decompress a wide variety of archives") {
+		t.Errorf("expected clean description in output:\n%s", out)
+	}
+}
+
+func TestParser_MapInterfaceChanAndGenericFields(t *testing.T) {
+	tmpdir := t.TempDir()
+	initTempModule(t, tmpdir)
+
+	source := `package testdata
+type Box[T any] struct {
+	// Value the boxed value
+	Value T ` + "`yaml:\"value\"`" + `
+}
+type Config struct {
+	// Labels free-form key/value labels
+	Labels map[string]string ` + "`yaml:\"labels\"`" + `
+	// Installs install records keyed by version
+	Installs map[string]*ToolInstall ` + "`yaml:\"installs\"`" + `
+	// Hook a user-supplied callback
+	Hook func(string) error ` + "`yaml:\"-\"`" + `
+	// Done signals completion
+	Done chan bool ` + "`yaml:\"-\"`" + `
+	// Extra arbitrary extension data
+	Extra interface{} ` + "`yaml:\"extra\"`" + `
+	// Boxed a generic instantiation
+	Boxed Box[string] ` + "`yaml:\"boxed\"`" + `
+	// Grid a map keyed by a bracketed array type
+	Grid map[[2]int]string ` + "`yaml:\"grid\"`" + `
+}
+type ToolInstall struct {
+	// Version Semantic version string
+	Version string ` + "`yaml:\"version\"`" + `
+}
+`
+	writeTempGoFile(t, tmpdir, "config.go", source)
+
+	p := NewParser()
+	if err := p.Load(tmpdir, "", true); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := p.structs[testdataPkg+".Config"]
+	byName := map[string]FieldInfo{}
+	for _, f := range cfg.Fields {
+		byName[f.Name] = f
+	}
+
+	if got := byName["Labels"].Type; got != "map[string]string" {
+		t.Errorf("expected Labels type map[string]string, got %q", got)
+	}
+	if got := byName["Installs"].Type; got != "map[string]*ToolInstall" {
+		t.Errorf("expected Installs type map[string]*ToolInstall, got %q", got)
+	}
+	if byName["Installs"].elemKey != testdataPkg+".ToolInstall" {
+		t.Errorf("expected Installs elemKey to resolve to ToolInstall, got %q", byName["Installs"].elemKey)
+	}
+	if got := byName["Hook"].Type; got != "func(string) error" {
+		t.Errorf("expected Hook type func(string) error, got %q", got)
+	}
+	if got := byName["Done"].Type; got != "chan bool" {
+		t.Errorf("expected Done type chan bool, got %q", got)
+	}
+	if got := byName["Extra"].Type; got != "interface{}" {
+		t.Errorf("expected Extra type interface{}, got %q", got)
+	}
+	if got := byName["Boxed"].Type; got != "Box[string]" {
+		t.Errorf("expected Boxed type Box[string], got %q", got)
+	}
+	if got := byName["Grid"].Type; got != "map[[2]int]string" {
+		t.Errorf("expected Grid type map[[2]int]string, got %q", got)
+	}
+
+	var buf bytes.Buffer
+	if err := (YAMLRenderer{}).Render(&buf, p, cfg); err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "labels: <map[string]string>") {
+		t.Errorf("expected map field header in output:\n%s", out)
+	}
+	if !strings.Contains(out, "key: <string>") {
+		t.Errorf("expected map example entry in output:\n%s", out)
+	}
+	if !strings.Contains(out, "key: <*ToolInstall>") {
+		t.Errorf("expected map-of-struct example entry in output:\n%s", out)
+	}
+	if !strings.Contains(out, "grid: <map[[2]int]string>") {
+		t.Errorf("expected Grid field header in output:\n%s", out)
+	}
+	if strings.Contains(out, "key: <int]string>") {
+		t.Errorf("expected Grid's value type 'string' to be extracted past the bracketed key's own ']', got:\n%s", out)
+	}
+}
+
+func TestParseFieldMarkers(t *testing.T) {
+	tmpdir := t.TempDir()
+	initTempModule(t, tmpdir)
+
+	source := `package testdata
+type Config struct {
+	// Replicas Number of replicas to run
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10
+	Replicas int ` + "`yaml:\"replicas\"`" + `
+	// Mode Deployment mode
+	// +kubebuilder:validation:Enum=dev;staging;prod
+	// +kubebuilder:example=dev
+	Mode string ` + "`yaml:\"mode\"`" + `
+	// Name Required display name
+	// +kubebuilder:validation:Required
+	Name string ` + "`yaml:\"name\"`" + `
+}`
+	writeTempGoFile(t, tmpdir, "config.go", source)
+
+	p := NewParser()
+	if err := p.Load(tmpdir, "", true); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := p.structs[testdataPkg+".Config"]
+	byName := map[string]FieldInfo{}
+	for _, f := range cfg.Fields {
+		byName[f.Name] = f
+	}
+
+	replicas := byName["Replicas"]
+	if replicas.Description != "Replicas Number of replicas to run" {
+		t.Errorf("expected marker lines stripped from description, got %q", replicas.Description)
+	}
+	if got := replicas.Markers["default"]; len(got) != 1 || got[0] != "3" {
+		t.Errorf("expected default marker [3], got %v", got)
+	}
+	if got := replicas.Markers["minimum"]; len(got) != 1 || got[0] != "1" {
+		t.Errorf("expected minimum marker [1], got %v", got)
+	}
+	if got := replicas.Markers["maximum"]; len(got) != 1 || got[0] != "10" {
+		t.Errorf("expected maximum marker [10], got %v", got)
+	}
+
+	mode := byName["Mode"]
+	if got := mode.Markers["enum"]; len(got) != 3 || got[0] != "dev" || got[2] != "prod" {
+		t.Errorf("expected enum marker [dev staging prod], got %v", got)
+	}
+	if got := mode.Markers["example"]; len(got) != 1 || got[0] != "dev" {
+		t.Errorf("expected example marker [dev], got %v", got)
+	}
+
+	if _, ok := byName["Name"].Markers["required"]; !ok {
+		t.Errorf("expected required marker on Name field, got %v", byName["Name"].Markers)
+	}
+
+	var buf bytes.Buffer
+	if err := (YAMLRenderer{}).Render(&buf, p, cfg); err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "# default: 3") {
+		t.Errorf("expected default marker comment in output:\n%s", out)
+	}
+	if !strings.Contains(out, "# enum: dev, staging, prod") {
+		t.Errorf("expected enum marker comment in output:\n%s", out)
+	}
+	if !strings.Contains(out, "# required") {
+		t.Errorf("expected required marker comment in output:\n%s", out)
+	}
+}
+
+// TestParser_ResolvesStructFromImportedPackage exercises go/types resolution
+// across package boundaries: a field typed from a second, imported package
+// within the same module must still resolve to a struct key Parser can look
+// up, and renderers must be able to recurse into it.
+func TestParser_ResolvesStructFromImportedPackage(t *testing.T) {
+	tmpdir := t.TempDir()
+	initTempModule(t, tmpdir)
+
+	addrDir := filepath.Join(tmpdir, "addr")
+	if err := os.MkdirAll(addrDir, 0700); err != nil {
+		t.Fatalf("failed to create addr dir: %v", err)
+	}
+	writeTempGoFile(t, addrDir, "addr.go", `package addr
+
+// Address is a postal address defined in its own package.
+type Address struct {
+	// City the city name
+	City string `+"`yaml:\"city\"`"+`
+}
+`)
+
+	writeTempGoFile(t, tmpdir, "person.go", `package testdata
+
+import "example.com/testdata/addr"
+
+// Person references a struct defined in an imported package.
+type Person struct {
+	// Home the person's home address
+	Home addr.Address `+"`yaml:\"home\"`"+`
+}
+`)
+
+	p := NewParser()
+	if err := p.Load(tmpdir, "", true); err != nil {
+		t.Fatal(err)
+	}
+
+	const addrKey = "example.com/testdata/addr.Address"
+	if _, ok := p.structs[addrKey]; !ok {
+		t.Fatalf("expected %s to be parsed, got %v", addrKey, sortedKeys(p.structs))
+	}
+
+	person := p.structs[testdataPkg+".Person"]
+	var home FieldInfo
+	for _, f := range person.Fields {
+		if f.Name == "Home" {
+			home = f
+		}
+	}
+	if home.Type != "addr.Address" {
+		t.Errorf("expected Home field type addr.Address, got %q", home.Type)
+	}
+	if home.elemKey != addrKey {
+		t.Errorf("expected Home field elemKey %q, got %q", addrKey, home.elemKey)
+	}
+
+	var buf bytes.Buffer
+	if err := (YAMLRenderer{}).Render(&buf, p, person); err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "city: <string>") {
+		t.Errorf("expected imported Address struct to be expanded in output:\n%s", out)
+	}
+}